@@ -0,0 +1,93 @@
+// Package access provides an http.Handler middleware that writes one
+// structured access record per request through the core logger package.
+package access
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gozjw/go-camp/logger"
+)
+
+// ResponseWriter wraps http.ResponseWriter to capture the status code
+// and byte count of a response for access logging.
+type ResponseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *ResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *ResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+// Status returns the response status code, defaulting to 200 if the
+// handler never called WriteHeader.
+func (w *ResponseWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// BytesWritten returns the number of response body bytes written.
+func (w *ResponseWriter) BytesWritten() int {
+	return w.bytes
+}
+
+// ClientIP returns the best guess at the originating client address,
+// preferring X-Forwarded-For and X-Real-IP over r.RemoteAddr since
+// requests are commonly proxied.
+func ClientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		if i := strings.IndexByte(fwd, ','); i >= 0 {
+			fwd = fwd[:i]
+		}
+		return strings.TrimSpace(fwd)
+	}
+	if rip := r.Header.Get("X-Real-IP"); rip != "" {
+		return rip
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// Middleware wraps next, writing an access record for every request it
+// serves: method, path, status, bytes, remote IP, latency, user agent
+// and request id.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rw := &ResponseWriter{ResponseWriter: w}
+
+		next.ServeHTTP(rw, r)
+
+		fields := map[string]interface{}{
+			"method":     r.Method,
+			"path":       r.URL.Path,
+			"status":     rw.Status(),
+			"bytes":      rw.BytesWritten(),
+			"remote_ip":  ClientIP(r),
+			"latency_ms": time.Since(start).Milliseconds(),
+			"user_agent": r.UserAgent(),
+			"request_id": r.Header.Get("X-Request-Id"),
+		}
+		logger.Access(fields, fmt.Sprintf("%s %s %d", r.Method, r.URL.Path, rw.Status()))
+	})
+}
@@ -0,0 +1,116 @@
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gozjw/go-camp/logger"
+)
+
+type capturingAdapter struct {
+	logs []*logger.Log
+}
+
+func (c *capturingAdapter) Name() string              { return "capture" }
+func (c *capturingAdapter) Write(l *logger.Log) error { c.logs = append(c.logs, l); return nil }
+func (c *capturingAdapter) Flush() error              { return nil }
+func (c *capturingAdapter) Close() error              { return nil }
+
+// TestMiddlewareWritesAccessRecord also guards against the MinLevel
+// bypass regression: access records must get through even with a
+// restrictive MinLevel, since they're a different axis from app
+// verbosity.
+func TestMiddlewareWritesAccessRecord(t *testing.T) {
+	capture := &capturingAdapter{}
+	logger.Register("access-test-capture", func(json.RawMessage) (logger.Adapter, error) {
+		return capture, nil
+	})
+	logger.Init(logger.Config{
+		Adapters: []logger.AdapterConfig{{Name: "access-test-capture"}},
+		MinLevel: "ERR",
+	})
+	defer logger.Close()
+
+	body := "short and stout"
+	handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/brew", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.1")
+	req.Header.Set("User-Agent", "kettle/1.0")
+	req.Header.Set("X-Request-Id", "req-123")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("response status = %d, want %d", rec.Code, http.StatusTeapot)
+	}
+	if err := logger.Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(capture.logs) != 1 {
+		t.Fatalf("expected exactly one access record, got %d", len(capture.logs))
+	}
+	fields := capture.logs[0].Fields
+	want := map[string]interface{}{
+		"method":     http.MethodGet,
+		"path":       "/brew",
+		"status":     http.StatusTeapot,
+		"bytes":      len(body),
+		"remote_ip":  "203.0.113.5",
+		"user_agent": "kettle/1.0",
+		"request_id": "req-123",
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("fields[%q] = %v, want %v", k, fields[k], v)
+		}
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	cases := []struct {
+		name   string
+		setup  func(r *http.Request)
+		remote string
+		want   string
+	}{
+		{
+			name:  "x-forwarded-for",
+			setup: func(r *http.Request) { r.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.1") },
+			want:  "203.0.113.5",
+		},
+		{
+			name:  "x-real-ip",
+			setup: func(r *http.Request) { r.Header.Set("X-Real-IP", "203.0.113.9") },
+			want:  "203.0.113.9",
+		},
+		{
+			name:   "remote-addr-fallback",
+			remote: "198.51.100.2:4242",
+			want:   "198.51.100.2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.remote != "" {
+				req.RemoteAddr = tc.remote
+			}
+			if tc.setup != nil {
+				tc.setup(req)
+			}
+			if got := ClientIP(req); got != tc.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
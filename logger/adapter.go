@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Adapter is one output destination for log records. Register implements
+// and registers a new kind; Init enables instances of it via
+// Config.Adapters.
+type Adapter interface {
+	Write(log *Log) error
+	// Flush persists any buffered records to their destination without
+	// closing it, so a mid-run Flush actually reaches disk/network.
+	Flush() error
+	Close() error
+	Name() string
+}
+
+// AdapterFactory builds an Adapter from its raw JSON config, as supplied
+// in an AdapterConfig.
+type AdapterFactory func(cfg json.RawMessage) (Adapter, error)
+
+var (
+	adapterFactories = make(map[string]AdapterFactory)
+	adapterRegMux    sync.RWMutex
+)
+
+// Register makes an adapter factory available under name for use in
+// Config.Adapters. It is meant to be called from adapter packages' init
+// functions.
+func Register(name string, factory AdapterFactory) {
+	adapterRegMux.Lock()
+	defer adapterRegMux.Unlock()
+	adapterFactories[name] = factory
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+)
+
+// connAdapterConfig is the Config.Adapters payload for the "conn"
+// adapter, which writes to a TCP or UDP socket. ReconnectOnMsg redials
+// before every write (matching Beego's ConnWriter); Reconnect redials
+// once and retries after a failed write.
+type connAdapterConfig struct {
+	Net            string
+	Addr           string
+	ReconnectOnMsg bool
+	Reconnect      bool
+}
+
+type connAdapter struct {
+	cfg  connAdapterConfig
+	conn net.Conn
+	mux  sync.Mutex
+}
+
+func init() {
+	Register("conn", newConnAdapter)
+}
+
+func newConnAdapter(cfg json.RawMessage) (Adapter, error) {
+	c := connAdapterConfig{Net: "tcp"}
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &c); err != nil {
+			return nil, err
+		}
+	}
+	return &connAdapter{cfg: c}, nil
+}
+
+func (a *connAdapter) Name() string { return "conn" }
+
+func (a *connAdapter) Write(log *Log) error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	if a.cfg.ReconnectOnMsg {
+		a.closeConn()
+	}
+	if a.conn == nil {
+		if err := a.dial(); err != nil {
+			return err
+		}
+	}
+
+	msg := []byte(formatLine(log, false))
+	if _, err := a.conn.Write(msg); err != nil {
+		if !a.cfg.Reconnect {
+			return err
+		}
+		a.closeConn()
+		if err := a.dial(); err != nil {
+			return err
+		}
+		_, err = a.conn.Write(msg)
+		return err
+	}
+	return nil
+}
+
+func (a *connAdapter) dial() error {
+	conn, err := net.Dial(a.cfg.Net, a.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	a.conn = conn
+	return nil
+}
+
+func (a *connAdapter) closeConn() {
+	if a.conn != nil {
+		a.conn.Close()
+		a.conn = nil
+	}
+}
+
+// Flush is a no-op: Write is unbuffered, so every record is already on
+// the wire once it returns.
+func (a *connAdapter) Flush() error { return nil }
+
+func (a *connAdapter) Close() error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.closeConn()
+	return nil
+}
@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// consoleAdapterConfig is the Config.Adapters payload for the "console"
+// adapter.
+type consoleAdapterConfig struct {
+	UseColor bool
+}
+
+type consoleAdapter struct {
+	useColor bool
+}
+
+func init() {
+	Register("console", newConsoleAdapter)
+}
+
+func newConsoleAdapter(cfg json.RawMessage) (Adapter, error) {
+	var c consoleAdapterConfig
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &c); err != nil {
+			return nil, err
+		}
+	}
+	return &consoleAdapter{useColor: c.UseColor}, nil
+}
+
+func (a *consoleAdapter) Name() string { return "console" }
+
+func (a *consoleAdapter) Write(log *Log) error {
+	_, err := fmt.Print(formatLine(log, a.useColor))
+	return err
+}
+
+// Flush is a no-op: Write goes straight to stdout, unbuffered.
+func (a *consoleAdapter) Flush() error { return nil }
+
+func (a *consoleAdapter) Close() error { return nil }
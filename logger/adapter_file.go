@@ -0,0 +1,278 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileAdapterConfig is the Config.Adapters payload for the "file"
+// adapter, which reproduces the logger's original behavior: one file per
+// level, cut by size and, by default, daily - matching the unconditional
+// daily rotation of the original logger before adapters existed.
+//
+// DateSlice is one of "" (daily, the default), "y", "m", "d" or "h".
+// MaxBackups and MaxAgeDays prune rotated files during rotation;
+// Compress gzips them in the background once they've been cut. Each
+// rotation only looks at the files of the one stem being cut or retired,
+// so MaxBackups and MaxAgeDays count and age files within a single slice;
+// files left by earlier slices are not looked at again.
+type fileAdapterConfig struct {
+	LogDir     string
+	MaxSize    int64
+	DateSlice  string
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+type fileAdapter struct {
+	logDir     string
+	maxSize    int64
+	dateFormat string
+	maxBackups int
+	maxAgeDays int
+	compress   bool
+
+	fileMap map[string]*os.File
+	mux     sync.Mutex
+
+	ticker *time.Ticker
+	stopCh chan struct{}
+}
+
+func init() {
+	Register("file", newFileAdapter)
+}
+
+func newFileAdapter(cfg json.RawMessage) (Adapter, error) {
+	c := fileAdapterConfig{
+		LogDir:  "./log/",
+		MaxSize: 1 * 1024 * 1024 * 1024,
+	}
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &c); err != nil {
+			return nil, err
+		}
+	}
+	if c.LogDir == "" {
+		c.LogDir = "./log/"
+	}
+	if c.MaxSize == 0 {
+		c.MaxSize = 1 * 1024 * 1024 * 1024
+	}
+
+	a := &fileAdapter{
+		logDir:     c.LogDir,
+		maxSize:    c.MaxSize,
+		dateFormat: dateSliceFormat(c.DateSlice),
+		maxBackups: c.MaxBackups,
+		maxAgeDays: c.MaxAgeDays,
+		compress:   c.Compress,
+		fileMap:    make(map[string]*os.File),
+		stopCh:     make(chan struct{}),
+	}
+	a.ticker = time.NewTicker(time.Minute)
+	go a.watchRotation()
+	return a, nil
+}
+
+// dateSliceFormat translates a DateSlice setting into the time.Format
+// layout used for the on-disk file stem. "" (the default) means daily,
+// matching the logger's original unconditional daily rotation.
+func dateSliceFormat(slice string) string {
+	switch slice {
+	case "y":
+		return "2006"
+	case "m":
+		return "2006-01"
+	case "h":
+		return "2006-01-02-15"
+	default:
+		return "2006-01-02"
+	}
+}
+
+func (a *fileAdapter) Name() string { return "file" }
+
+func (a *fileAdapter) Write(log *Log) error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	var file *os.File
+	var ok bool
+	var level string
+
+	switch log.Level {
+	case debugLevel, warnLevel, errorLevel, fatalLevel, accessLevel:
+		level = log.Level
+	default:
+		level = infoLevel
+	}
+
+	if file, ok = a.fileMap[level]; ok {
+		stat, _ := file.Stat()
+		stem := strings.Split(stat.Name(), ".")[0]
+		expired := a.stemExpired(stem, log.Time)
+		if stat.Size() > a.maxSize || expired {
+			file.Close()
+			if expired {
+				// The old stem is retired for good - not just cut for
+				// size within the same period - so its file is itself
+				// eligible for retention/compression, not only whatever
+				// size-overflow backups already sit next to it.
+				fileDir := a.logDir + "/" + strings.ToLower(level) + "/"
+				a.pruneRotatedFiles(fileDir, stem)
+			}
+			file = a.newFile(level)
+			a.fileMap[level] = file
+		}
+	} else {
+		file = a.newFile(level)
+		a.fileMap[level] = file
+	}
+
+	_, err := file.WriteString(formatLine(log, false))
+	return err
+}
+
+// stemExpired reports whether at is in a different slice than the one
+// the file's current stem was named for.
+func (a *fileAdapter) stemExpired(stem string, at time.Time) bool {
+	return strings.Index(at.In(timeLocation).Format(a.dateFormat), stem) != 0
+}
+
+// watchRotation cuts idle files at slice boundaries even when nothing is
+// being written, so a quiet process doesn't keep appending to yesterday's
+// file until its next log line.
+func (a *fileAdapter) watchRotation() {
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		case now := <-a.ticker.C:
+			a.mux.Lock()
+			for level, file := range a.fileMap {
+				stat, err := file.Stat()
+				if err != nil {
+					continue
+				}
+				stem := strings.Split(stat.Name(), ".")[0]
+				if a.stemExpired(stem, now) {
+					file.Close()
+					fileDir := a.logDir + "/" + strings.ToLower(level) + "/"
+					a.pruneRotatedFiles(fileDir, stem)
+					a.fileMap[level] = a.newFile(level)
+				}
+			}
+			a.mux.Unlock()
+		}
+	}
+}
+
+// Flush fsyncs every currently open file without closing it.
+func (a *fileAdapter) Flush() error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	for _, f := range a.fileMap {
+		if err := f.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *fileAdapter) Close() error {
+	if a.ticker != nil {
+		a.ticker.Stop()
+		close(a.stopCh)
+	}
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	for level, f := range a.fileMap {
+		f.Sync()
+		f.Close()
+		delete(a.fileMap, level)
+	}
+	return nil
+}
+
+func (a *fileAdapter) fileStem(t time.Time) string {
+	return t.In(timeLocation).Format(a.dateFormat)
+}
+
+func (a *fileAdapter) newFile(level string) *os.File {
+	stem := a.fileStem(time.Now())
+	fileDir := a.logDir + "/" + strings.ToLower(level) + "/"
+	fileName := path.Clean(fileDir + stem + ".log")
+	ok := pathExists(fileDir)
+	if !ok {
+		if err := os.MkdirAll(fileDir, os.ModePerm); err != nil {
+			panic(err)
+		}
+	} else {
+		if getFileSize(fileName) > a.maxSize {
+			renameFile(fileDir, stem)
+			a.pruneRotatedFiles(fileDir, stem)
+		}
+	}
+	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0664)
+	if err != nil {
+		return nil
+	}
+	return file
+}
+
+func renameFile(fileDir, date string) {
+	files, _ := ioutil.ReadDir(fileDir)
+	var dateFile []os.FileInfo
+	for _, onefile := range files {
+		fileName := onefile.Name()
+		if !onefile.IsDir() && strings.Index(fileName, date) == 0 {
+			dateFile = append(dateFile, onefile)
+		}
+	}
+	for i := 0; i < len(dateFile)-1; i++ {
+		for j := i + 1; j < len(dateFile); j++ {
+			if getSuffix(dateFile[i].Name()) < getSuffix(dateFile[j].Name()) {
+				dateFile[i], dateFile[j] = dateFile[j], dateFile[i]
+			}
+		}
+	}
+	for i := range dateFile {
+		os.Rename(fileDir+dateFile[i].Name(),
+			fileDir+fmt.Sprintf("%s_%d.log", date, len(dateFile)-i))
+	}
+}
+
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil || os.IsExist(err)
+}
+
+func getFileSize(path string) int64 {
+	if !pathExists(path) {
+		return 0
+	}
+	fileInfo, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return fileInfo.Size()
+}
+
+func getSuffix(fileName string) int {
+	suffix := 0
+	srts := strings.Split(fileName, "_")
+	if len(srts) > 1 {
+		srts := strings.Split(srts[1], ".")
+		suffix, _ = strconv.Atoi(srts[0])
+	}
+	return suffix
+}
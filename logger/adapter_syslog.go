@@ -0,0 +1,65 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package logger
+
+import (
+	"encoding/json"
+	"log/syslog"
+)
+
+// syslogAdapterConfig is the Config.Adapters payload for the "syslog"
+// adapter. Network/Addr empty dials the local syslog daemon.
+type syslogAdapterConfig struct {
+	Network string
+	Addr    string
+	Tag     string
+}
+
+type syslogAdapter struct {
+	writer *syslog.Writer
+}
+
+func init() {
+	Register("syslog", newSyslogAdapter)
+}
+
+func newSyslogAdapter(cfg json.RawMessage) (Adapter, error) {
+	c := syslogAdapterConfig{Tag: "logger"}
+	if len(cfg) > 0 {
+		if err := json.Unmarshal(cfg, &c); err != nil {
+			return nil, err
+		}
+	}
+	w, err := syslog.Dial(c.Network, c.Addr, syslog.LOG_INFO, c.Tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogAdapter{writer: w}, nil
+}
+
+func (a *syslogAdapter) Name() string { return "syslog" }
+
+func (a *syslogAdapter) Write(log *Log) error {
+	msg := formatLine(log, false)
+	switch log.Level {
+	case debugLevel:
+		return a.writer.Debug(msg)
+	case warnLevel:
+		return a.writer.Warning(msg)
+	case errorLevel:
+		return a.writer.Err(msg)
+	case fatalLevel:
+		return a.writer.Crit(msg)
+	default:
+		return a.writer.Info(msg)
+	}
+}
+
+// Flush is a no-op: Write is unbuffered, so every record is already sent
+// once it returns.
+func (a *syslogAdapter) Flush() error { return nil }
+
+func (a *syslogAdapter) Close() error {
+	return a.writer.Close()
+}
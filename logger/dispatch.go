@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Overflow policies for Push when logChan is full.
+const (
+	OverflowBlock      = "Block"
+	OverflowDropOldest = "DropOldest"
+	OverflowDropNewest = "DropNewest"
+)
+
+var (
+	logChan = make(chan *Log, 1024)
+
+	startedFlag    int32
+	overflowPolicy string
+	droppedCount   int64
+
+	stopCh  chan struct{}
+	doneCh  chan struct{}
+	flushCh chan chan struct{}
+)
+
+// Stats reports logger health counters.
+type Stats struct {
+	// Dropped is the number of records discarded because logChan was
+	// full and OverflowPolicy wasn't Block.
+	Dropped int64
+}
+
+func GetStats() Stats {
+	return Stats{Dropped: atomic.LoadInt64(&droppedCount)}
+}
+
+// startLogger marks the logger started and (re)creates its channels. It
+// runs synchronously in Init, before the worker goroutine exists, so a
+// racing second Init call always panics against the real state rather
+// than the "started" flag a not-yet-scheduled goroutine hasn't set yet.
+func startLogger(config Config) {
+	if !atomic.CompareAndSwapInt32(&startedFlag, 0, 1) {
+		panic("logger started")
+	}
+
+	if config.ChannelSize == 0 {
+		logChan = make(chan *Log, 1024)
+	} else {
+		logChan = make(chan *Log, config.ChannelSize)
+	}
+
+	overflowPolicy = config.OverflowPolicy
+	if overflowPolicy == "" {
+		overflowPolicy = OverflowBlock
+	}
+	atomic.StoreInt64(&droppedCount, 0)
+
+	stopCh = make(chan struct{})
+	doneCh = make(chan struct{})
+	flushCh = make(chan chan struct{})
+}
+
+// Push enqueues log for dispatch to every adapter. Under DropNewest or
+// DropOldest it never blocks the caller: DropNewest discards log itself
+// when logChan is full, DropOldest makes room by discarding the oldest
+// queued record instead. Block, and any other policy name, waits for
+// room in logChan.
+func Push(log Log) {
+	if atomic.LoadInt32(&startedFlag) == 0 {
+		panic("logger not start")
+	}
+	entry := &log
+
+	switch overflowPolicy {
+	case OverflowDropNewest:
+		select {
+		case logChan <- entry:
+		default:
+			atomic.AddInt64(&droppedCount, 1)
+		}
+	case OverflowDropOldest:
+		select {
+		case logChan <- entry:
+		default:
+			select {
+			case <-logChan:
+				atomic.AddInt64(&droppedCount, 1)
+			default:
+			}
+			select {
+			case logChan <- entry:
+			default:
+				atomic.AddInt64(&droppedCount, 1)
+			}
+		}
+	default: // OverflowBlock
+		logChan <- entry
+	}
+}
+
+// run is the worker goroutine started by Init. It dispatches every
+// record to all enabled adapters, drains logChan before honoring a
+// Flush or Close so no buffered record is lost, and only exits once
+// Close has been called.
+func run() {
+	defer close(doneCh)
+	for {
+		select {
+		case log := <-logChan:
+			dispatch(log)
+		case ack := <-flushCh:
+			drainChan()
+			for _, a := range adapters {
+				a.Flush()
+			}
+			close(ack)
+		case <-stopCh:
+			drainChan()
+			for _, a := range adapters {
+				a.Close()
+			}
+			return
+		}
+	}
+}
+
+func drainChan() {
+	for {
+		select {
+		case log := <-logChan:
+			dispatch(log)
+		default:
+			return
+		}
+	}
+}
+
+func dispatch(log *Log) {
+	for _, a := range adapters {
+		a.Write(log)
+	}
+}
+
+// Flush blocks until every record queued so far has been written and
+// flushed to its adapters (fsyncing open files), or ctx is done.
+func Flush(ctx context.Context) error {
+	ack := make(chan struct{})
+	select {
+	case flushCh <- ack:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-ack:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close drains logChan, closes every adapter (which fsyncs and closes
+// their open files), and stops the worker goroutine. Only the first call
+// after an Init does this; later calls return nil without doing anything.
+func Close() error {
+	if !atomic.CompareAndSwapInt32(&startedFlag, 1, 0) {
+		return nil
+	}
+	close(stopCh)
+	<-doneCh
+	return nil
+}
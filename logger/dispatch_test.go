@@ -0,0 +1,158 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// blockingAdapter lets a test pin the worker goroutine mid-Write so it
+// can deterministically fill logChan and exercise the overflow
+// policies, instead of racing Push against however fast the worker
+// happens to drain.
+type blockingAdapter struct {
+	entered chan struct{}
+	release chan struct{}
+
+	mu   sync.Mutex
+	logs []*Log
+}
+
+func (b *blockingAdapter) Name() string { return "blocking" }
+
+func (b *blockingAdapter) Write(l *Log) error {
+	select {
+	case b.entered <- struct{}{}:
+	default:
+	}
+	<-b.release
+
+	b.mu.Lock()
+	b.logs = append(b.logs, l)
+	b.mu.Unlock()
+	return nil
+}
+
+func (b *blockingAdapter) Flush() error { return nil }
+
+func (b *blockingAdapter) Close() error { return nil }
+
+func (b *blockingAdapter) recorded() []*Log {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Log(nil), b.logs...)
+}
+
+func TestPushDropNewestDiscardsWhenFull(t *testing.T) {
+	adapter := &blockingAdapter{entered: make(chan struct{}, 1), release: make(chan struct{})}
+	Register("dispatch-test-dropnewest", func(json.RawMessage) (Adapter, error) { return adapter, nil })
+	Init(Config{
+		ChannelSize:    1,
+		OverflowPolicy: OverflowDropNewest,
+		Adapters:       []AdapterConfig{{Name: "dispatch-test-dropnewest"}},
+	})
+
+	Push(Log{Message: "0"})
+	<-adapter.entered // worker is now blocked inside Write for "0"
+
+	Push(Log{Message: "1"}) // fills the size-1 buffer
+	Push(Log{Message: "2"}) // buffer full: DropNewest discards this one
+
+	close(adapter.release)
+	if err := Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := GetStats().Dropped; got != 1 {
+		t.Fatalf("Dropped = %d, want 1", got)
+	}
+	logs := adapter.recorded()
+	if len(logs) != 2 || logs[0].Message != "0" || logs[1].Message != "1" {
+		t.Fatalf("recorded = %v, want [0 1]", logs)
+	}
+}
+
+func TestPushDropOldestDiscardsOldestQueued(t *testing.T) {
+	adapter := &blockingAdapter{entered: make(chan struct{}, 1), release: make(chan struct{})}
+	Register("dispatch-test-dropoldest", func(json.RawMessage) (Adapter, error) { return adapter, nil })
+	Init(Config{
+		ChannelSize:    1,
+		OverflowPolicy: OverflowDropOldest,
+		Adapters:       []AdapterConfig{{Name: "dispatch-test-dropoldest"}},
+	})
+
+	Push(Log{Message: "0"})
+	<-adapter.entered // worker is now blocked inside Write for "0"
+
+	Push(Log{Message: "1"}) // fills the size-1 buffer
+	Push(Log{Message: "2"}) // buffer full: DropOldest evicts "1" to make room
+
+	close(adapter.release)
+	if err := Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := GetStats().Dropped; got != 1 {
+		t.Fatalf("Dropped = %d, want 1", got)
+	}
+	logs := adapter.recorded()
+	if len(logs) != 2 || logs[0].Message != "0" || logs[1].Message != "2" {
+		t.Fatalf("recorded = %v, want [0 2]", logs)
+	}
+}
+
+func TestFlushDrainsQueueBeforeReturning(t *testing.T) {
+	rec := &recordingAdapter{}
+	Register("dispatch-test-flush", func(json.RawMessage) (Adapter, error) { return rec, nil })
+	Init(Config{
+		ChannelSize: 16,
+		Adapters:    []AdapterConfig{{Name: "dispatch-test-flush"}},
+	})
+	defer Close()
+
+	for i := 0; i < 10; i++ {
+		Push(Log{Message: fmt.Sprintf("%d", i)})
+	}
+
+	if err := Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.logs) != 10 {
+		t.Fatalf("expected Flush to drain all 10 queued records, got %d", len(rec.logs))
+	}
+}
+
+func TestCloseDrainsQueueAndStopsWorker(t *testing.T) {
+	rec := &recordingAdapter{}
+	Register("dispatch-test-close", func(json.RawMessage) (Adapter, error) { return rec, nil })
+	Init(Config{
+		ChannelSize: 16,
+		Adapters:    []AdapterConfig{{Name: "dispatch-test-close"}},
+	})
+
+	for i := 0; i < 5; i++ {
+		Push(Log{Message: fmt.Sprintf("%d", i)})
+	}
+
+	if err := Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.logs) != 5 {
+		t.Fatalf("expected Close to drain all 5 queued records before stopping, got %d", len(rec.logs))
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected Push after Close to panic")
+		}
+	}()
+	Push(Log{Message: "late"})
+}
@@ -0,0 +1,57 @@
+package logger
+
+import "fmt"
+
+// Entry is a logger bound to a fixed set of structured fields, for
+// call sites that want every record they emit to carry the same
+// context (request id, user id, ...) without repeating it each time.
+type Entry struct {
+	fields map[string]interface{}
+}
+
+// With returns an Entry that attaches fields to every record it logs.
+func With(fields map[string]interface{}) *Entry {
+	return &Entry{fields: fields}
+}
+
+func (e *Entry) Debug(params ...interface{}) {
+	info(debugLevel, e.fields, params...)
+}
+
+func (e *Entry) Info(params ...interface{}) {
+	info(infoLevel, e.fields, params...)
+}
+
+func (e *Entry) Warn(params ...interface{}) {
+	info(warnLevel, e.fields, params...)
+}
+
+func (e *Entry) Error(params ...interface{}) {
+	info(errorLevel, e.fields, params...)
+}
+
+func (e *Entry) Debugf(format string, params ...interface{}) {
+	info(debugLevel, e.fields, fmt.Sprintf(format, params...))
+}
+
+func (e *Entry) Infof(format string, params ...interface{}) {
+	info(infoLevel, e.fields, fmt.Sprintf(format, params...))
+}
+
+func (e *Entry) Warnf(format string, params ...interface{}) {
+	info(warnLevel, e.fields, fmt.Sprintf(format, params...))
+}
+
+func (e *Entry) Errorf(format string, params ...interface{}) {
+	info(errorLevel, e.fields, fmt.Sprintf(format, params...))
+}
+
+func (e *Entry) Fatal(params ...interface{}) {
+	info(fatalLevel, e.fields, params...)
+	exit()
+}
+
+func (e *Entry) Fatalf(format string, params ...interface{}) {
+	info(fatalLevel, e.fields, fmt.Sprintf(format, params...))
+	exit()
+}
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// formatLine renders a Log the way every adapter expects a line to
+// look. The default is plain text; Config.JSONFormat switches to one
+// JSON object per record, and Config.Format lets callers supply their
+// own template instead.
+func formatLine(log *Log, useColor bool) string {
+	switch {
+	case jsonFormat:
+		return formatJSONLine(log)
+	case formatTemplate != "":
+		return applyFormat(formatTemplate, log, useColor)
+	default:
+		return formatPlainLine(log, useColor)
+	}
+}
+
+func formatPlainLine(log *Log, useColor bool) string {
+	level := log.Level
+	if useColor {
+		level = setLevelColor(level)
+	}
+
+	var result string
+	msgList := strings.Split(log.Message, "\n")
+	for i := range msgList {
+		result += fmt.Sprintf("%s [%s] [%s] %s",
+			log.Time.In(timeLocation).Format(timeFormart),
+			level,
+			log.Line,
+			msgList[i]) + "\n"
+	}
+	return result
+}
+
+// formatJSONLine emits one JSON object per record, merging Fields in
+// alongside the standard keys.
+func formatJSONLine(log *Log) string {
+	obj := make(map[string]interface{}, 5+len(log.Fields))
+	for k, v := range log.Fields {
+		obj[k] = v
+	}
+	obj["time"] = log.Time.In(timeLocation).Format(timeFormart)
+	obj["level"] = log.Level
+	obj["file"] = log.File
+	obj["line"] = log.LineNo
+	obj["msg"] = log.Message
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return string(b) + "\n"
+}
+
+// applyFormat substitutes %time%, %level%, %file%, %line%, %msg% and
+// %fields% tokens in tmpl.
+func applyFormat(tmpl string, log *Log, useColor bool) string {
+	level := log.Level
+	if useColor {
+		level = setLevelColor(level)
+	}
+	r := strings.NewReplacer(
+		"%time%", log.Time.In(timeLocation).Format(timeFormart),
+		"%level%", level,
+		"%file%", log.File,
+		"%line%", strconv.Itoa(log.LineNo),
+		"%msg%", log.Message,
+		"%fields%", formatFields(log.Fields),
+	)
+	return r.Replace(tmpl) + "\n"
+}
+
+func formatFields(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+func setLevelColor(level string) string {
+	var color int
+	switch level {
+	case infoLevel:
+		color = 32
+	case debugLevel:
+		color = 34
+	case warnLevel:
+		color = 33
+	case errorLevel:
+		color = 31
+	default:
+		color = 36
+	}
+	return fmt.Sprintf("\033[%dm%s\033[0m", color, level)
+}
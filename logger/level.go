@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Severity ordering: DBG < INF < WRN < ERR < FTL. accessLevel isn't part
+// of this ordering at all - it's a separate axis from app verbosity, and
+// info() bypasses severityOf for it entirely rather than pinning it to a
+// tier here.
+const (
+	sevDebug = iota
+	sevInfo
+	sevWarn
+	sevError
+	sevFatal
+)
+
+var minLevel int32 // sevDebug by default: nothing is filtered out
+
+func severityOf(level string) int {
+	switch level {
+	case debugLevel:
+		return sevDebug
+	case infoLevel:
+		return sevInfo
+	case warnLevel:
+		return sevWarn
+	case errorLevel:
+		return sevError
+	case fatalLevel:
+		return sevFatal
+	default:
+		return sevDebug
+	}
+}
+
+func currentLevel() int {
+	return int(atomic.LoadInt32(&minLevel))
+}
+
+// SetLevel sets the minimum severity that gets logged; records below it
+// are dropped before runtime.Caller runs or anything reaches logChan.
+// An unrecognized level (including "") disables filtering.
+func SetLevel(level string) {
+	atomic.StoreInt32(&minLevel, int32(severityOf(level)))
+}
+
+// GetLevel returns the current minimum severity.
+func GetLevel() string {
+	switch currentLevel() {
+	case sevDebug:
+		return debugLevel
+	case sevInfo:
+		return infoLevel
+	case sevWarn:
+		return warnLevel
+	case sevError:
+		return errorLevel
+	default:
+		return fatalLevel
+	}
+}
+
+// exit flushes whatever is queued, with a bounded wait so a stuck
+// adapter can't hang the process, then terminates it.
+func exit() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	Flush(ctx)
+	os.Exit(1)
+}
@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+type recordingAdapter struct {
+	logs []*Log
+}
+
+func (r *recordingAdapter) Name() string       { return "record" }
+func (r *recordingAdapter) Write(l *Log) error { r.logs = append(r.logs, l); return nil }
+func (r *recordingAdapter) Flush() error       { return nil }
+func (r *recordingAdapter) Close() error       { return nil }
+
+// TestAccessBypassesMinLevel guards against accessLevel being mapped
+// into the same severity tier as Debug: once MinLevel excludes debug
+// records, access records must still get through.
+func TestAccessBypassesMinLevel(t *testing.T) {
+	rec := &recordingAdapter{}
+	Register("record-test-access-bypass", func(json.RawMessage) (Adapter, error) { return rec, nil })
+
+	Init(Config{
+		Adapters: []AdapterConfig{{Name: "record-test-access-bypass"}},
+		MinLevel: errorLevel,
+	})
+	defer Close()
+
+	Access(map[string]interface{}{"path": "/x"}, "GET /x 200")
+	Info("dropped: below MinLevel")
+
+	if err := Flush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var sawAccess, sawInfo bool
+	for _, l := range rec.logs {
+		switch l.Level {
+		case accessLevel:
+			sawAccess = true
+		case infoLevel:
+			sawInfo = true
+		}
+	}
+	if !sawAccess {
+		t.Fatal("expected an access record to bypass MinLevel filtering")
+	}
+	if sawInfo {
+		t.Fatal("expected an info record below MinLevel to be dropped")
+	}
+}
@@ -1,14 +1,10 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path"
 	"runtime"
-	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -19,6 +15,20 @@ type Config struct {
 	ChannelSize    int
 	OutputScreen   bool
 	UseColor       bool
+	Adapters       []AdapterConfig
+	JSONFormat     bool
+	Format         string
+	OverflowPolicy string
+	MinLevel       string
+	CallerSkip     int
+}
+
+// AdapterConfig enables and configures one output adapter. Config is
+// passed through untouched to the named adapter's factory, so each
+// adapter defines its own shape for it.
+type AdapterConfig struct {
+	Name   string
+	Config json.RawMessage
 }
 
 const (
@@ -26,264 +36,163 @@ const (
 	infoLevel  = "INF"
 	warnLevel  = "WRN"
 	errorLevel = "ERR"
+	fatalLevel = "FTL"
+	// accessLevel is used by logger/access to keep its records in their
+	// own file bucket, separate from application logs.
+	accessLevel = "ACC"
 
-	fileName    = "2006-01-02"
 	timeFormart = "2006-01-02 15:04:05"
 )
 
 var (
-	logDir               = "./log/"
-	timeLocation         = time.Now().Location()
-	logFileMaxSize int64 = 1 * 1024 * 1024 * 1024
-	logChan              = make(chan *Log, 1024)
+	timeLocation = time.Now().Location()
 
-	outputScreen bool
-	useColor     bool
-	started      bool
-	fileMap      map[string]*os.File
-	mux          sync.Mutex
+	adapters       []Adapter
+	jsonFormat     bool
+	formatTemplate string
+	callerSkip     int
 )
 
+// Init starts the logger. It panics if called twice without an
+// intervening Close, and - unlike the adapters it wires up - does all of
+// its setup before returning, so a second call right after the first
+// always observes a fully started logger rather than racing it.
 func Init(config Config) {
-	if started {
-		panic("logger started")
-	}
-	if config.LogDir != "" {
-		logDir = config.LogDir
-	}
-	if config.LogFileMaxSize != 0 {
-		logFileMaxSize = config.LogFileMaxSize
-	}
+	startLogger(config)
+
 	if config.TimeLocation != nil {
 		timeLocation = config.TimeLocation
 	}
-	outputScreen = config.OutputScreen
-	useColor = config.UseColor
-	if config.ChannelSize == 0 {
-		logChan = make(chan *Log, 1024)
-	} else {
-		logChan = make(chan *Log, config.ChannelSize)
+	jsonFormat = config.JSONFormat
+	formatTemplate = config.Format
+	callerSkip = config.CallerSkip
+	SetLevel(config.MinLevel)
+
+	adapters = buildAdapters(config)
+
+	go run()
+}
+
+// buildAdapters resolves the configured adapters, falling back to the
+// file adapter (plus console, if OutputScreen was set) so callers that
+// never touched Config.Adapters keep today's behavior.
+func buildAdapters(config Config) []Adapter {
+	adapterConfigs := config.Adapters
+	if len(adapterConfigs) == 0 {
+		fileCfg, _ := json.Marshal(fileAdapterConfig{
+			LogDir:  config.LogDir,
+			MaxSize: config.LogFileMaxSize,
+		})
+		adapterConfigs = []AdapterConfig{{Name: "file", Config: fileCfg}}
+		if config.OutputScreen {
+			consoleCfg, _ := json.Marshal(consoleAdapterConfig{UseColor: config.UseColor})
+			adapterConfigs = append(adapterConfigs, AdapterConfig{Name: "console", Config: consoleCfg})
+		}
 	}
-	fileMap = make(map[string]*os.File)
 
-	go start()
+	result := make([]Adapter, 0, len(adapterConfigs))
+	for _, ac := range adapterConfigs {
+		factory, ok := adapterFactories[ac.Name]
+		if !ok {
+			panic("logger: unknown adapter " + ac.Name)
+		}
+		adapter, err := factory(ac.Config)
+		if err != nil {
+			panic(err)
+		}
+		result = append(result, adapter)
+	}
+	return result
 }
 
 type Log struct {
 	Level   string
 	Time    time.Time
 	Line    string
+	File    string
+	LineNo  int
 	Message string
-}
-
-func Push(log Log) {
-	mux.Lock()
-	defer mux.Unlock()
-	if !started {
-		panic("logger not start")
-	}
-	logChan <- &log
+	Fields  map[string]interface{}
 }
 
 func Debug(params ...interface{}) {
-	info(debugLevel, params...)
+	info(debugLevel, nil, params...)
 }
 
 func Info(params ...interface{}) {
-	info(infoLevel, params...)
+	info(infoLevel, nil, params...)
 }
 
 func Warn(params ...interface{}) {
-	info(warnLevel, params...)
+	info(warnLevel, nil, params...)
 }
 
 func Error(params ...interface{}) {
-	info(errorLevel, params...)
+	info(errorLevel, nil, params...)
 }
 
 func Debugf(format string, params ...interface{}) {
-	info(debugLevel, fmt.Sprintf(format, params...))
+	info(debugLevel, nil, fmt.Sprintf(format, params...))
 }
 
 func Infof(format string, params ...interface{}) {
-	info(infoLevel, fmt.Sprintf(format, params...))
+	info(infoLevel, nil, fmt.Sprintf(format, params...))
 }
 
 func Warnf(format string, params ...interface{}) {
-	info(warnLevel, fmt.Sprintf(format, params...))
+	info(warnLevel, nil, fmt.Sprintf(format, params...))
 }
 
 func Errorf(format string, params ...interface{}) {
-	info(errorLevel, fmt.Sprintf(format, params...))
+	info(errorLevel, nil, fmt.Sprintf(format, params...))
+}
+
+// Access logs a structured record at accessLevel, routed to its own
+// file bucket by adapters that bucket per level. It is the entry point
+// logger/access's middleware uses.
+func Access(fields map[string]interface{}, message string) {
+	info(accessLevel, fields, message)
+}
+
+// Fatal logs at fatalLevel, flushes, then calls os.Exit(1).
+func Fatal(params ...interface{}) {
+	info(fatalLevel, nil, params...)
+	exit()
+}
+
+// Fatalf is Fatal with fmt.Sprintf-style formatting.
+func Fatalf(format string, params ...interface{}) {
+	info(fatalLevel, nil, fmt.Sprintf(format, params...))
+	exit()
 }
 
-func info(level string, params ...interface{}) {
+// info builds and pushes a Log record. It is called both from the
+// package-level helpers and from Entry, always one frame above the
+// caller, so runtime.Caller(2+CallerSkip) always resolves to real user
+// code. Records below MinLevel are dropped before paying for the
+// runtime.Caller walk or a channel send. accessLevel is a different axis
+// from app verbosity entirely, so it always bypasses this filter.
+func info(level string, fields map[string]interface{}, params ...interface{}) {
+	if level != accessLevel && severityOf(level) < currentLevel() {
+		return
+	}
+
 	var message string
 	var messageList []string
 	for _, p := range params {
 		messageList = append(messageList, fmt.Sprintf("%+v", p))
 	}
 	message = strings.Join(messageList, " ")
-	function, _, _, _ := runtime.Caller(2)
+	function, _, _, _ := runtime.Caller(2 + callerSkip)
 	file, line := runtime.FuncForPC(function).FileLine(function)
 	log := Log{
 		Level:   level,
 		Time:    time.Now(),
 		Line:    fmt.Sprintf("%s:%d", file, line),
+		File:    file,
+		LineNo:  line,
 		Message: message,
+		Fields:  fields,
 	}
 	Push(log)
 }
-
-func newFile(level string) *os.File {
-	date := time.Now().In(timeLocation).Format(fileName)
-	fileDir := logDir + "/" + strings.ToLower(level) + "/"
-	var fileName = path.Clean(fileDir + date + ".log")
-	ok := pathExists(fileDir)
-	if !ok {
-		if err := os.MkdirAll(fileDir, os.ModePerm); err != nil {
-			panic(err)
-		}
-	} else {
-		if getFileSize(fileName) > logFileMaxSize {
-			renameFile(fileDir, date)
-		}
-	}
-	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0664)
-	if err != nil {
-		return nil
-	}
-	return file
-}
-
-func renameFile(fileDir, date string) {
-	files, _ := ioutil.ReadDir(fileDir)
-	var dateFile []os.FileInfo
-	for _, onefile := range files {
-		fileName := onefile.Name()
-		if !onefile.IsDir() && strings.Index(fileName, date) == 0 {
-			dateFile = append(dateFile, onefile)
-		}
-	}
-	for i := 0; i < len(dateFile)-1; i++ {
-		for j := i + 1; j < len(dateFile); j++ {
-			if getSuffix(dateFile[i].Name()) < getSuffix(dateFile[j].Name()) {
-				dateFile[i], dateFile[j] = dateFile[j], dateFile[i]
-			}
-		}
-	}
-	for i := range dateFile {
-		os.Rename(fileDir+dateFile[i].Name(),
-			fileDir+fmt.Sprintf("%s_%d.log", date, len(dateFile)-i))
-	}
-}
-
-func start() {
-	started = true
-	defer func() { started = false }()
-	for {
-		select {
-		case log := <-logChan:
-			write(log)
-		}
-	}
-}
-
-func write(log *Log) {
-	var file *os.File
-	var ok bool
-	var needNewFile bool
-	var level string
-
-	switch log.Level {
-	case debugLevel, warnLevel, errorLevel:
-		level = log.Level
-	default:
-		level = infoLevel
-	}
-
-	if file, ok = fileMap[level]; ok {
-		stat, _ := file.Stat()
-		date := strings.Split(stat.Name(), ".")[0]
-		if stat.Size() > logFileMaxSize ||
-			strings.Index(log.Time.In(timeLocation).Format(fileName), date) != 0 {
-			needNewFile = true
-		}
-	} else {
-		needNewFile = true
-	}
-
-	if needNewFile {
-		if file != nil {
-			file.Close()
-		}
-		file = newFile(level)
-		fileMap[level] = file
-	}
-
-	file.WriteString(formatLine(log))
-}
-
-func pathExists(path string) bool {
-	_, err := os.Stat(path)
-	return err == nil || os.IsExist(err)
-}
-
-func getFileSize(path string) int64 {
-	if !pathExists(path) {
-		return 0
-	}
-	fileInfo, err := os.Stat(path)
-	if err != nil {
-		return 0
-	}
-	return fileInfo.Size()
-}
-
-func getSuffix(fileName string) int {
-	suffix := 0
-	srts := strings.Split(fileName, "_")
-	if len(srts) > 1 {
-		srts := strings.Split(srts[1], ".")
-		suffix, _ = strconv.Atoi(srts[0])
-	}
-	return suffix
-}
-
-func formatLine(log *Log) string {
-	var result = ""
-	msgList := strings.Split(log.Message, "\n")
-	for i := range msgList {
-		if useColor {
-			log.Level = setLevelColor(log.Level)
-		}
-		result = result + fmt.Sprintf("%s [%s] [%s] %s",
-			log.Time.In(timeLocation).Format(timeFormart),
-			log.Level,
-			log.Line,
-			msgList[i]) + "\n"
-	}
-
-	if outputScreen {
-		fmt.Print(result)
-	}
-
-	return result
-}
-
-func setLevelColor(level string) string {
-	var color int
-	switch level {
-	case infoLevel:
-		color = 32
-	case debugLevel:
-		color = 34
-	case warnLevel:
-		color = 33
-	case errorLevel:
-		color = 31
-	default:
-		color = 36
-	}
-	return fmt.Sprintf("\033[%dm%s\033[0m", color, level)
-}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pruneRotatedFiles enforces maxBackups/maxAgeDays on the files left
+// behind for stem - both size-overflow "_N.log" backups from renameFile
+// and, when stem itself has just been retired by a date-boundary
+// rotation, stem+".log" itself - and kicks off background compression of
+// whatever survives when the adapter was configured with Compress.
+//
+// Callers must only pass a stem that is no longer the active file for
+// this level: either renameFile has already moved stem+".log" out of
+// the way, or the caller closed it and is about to replace it in
+// fileMap with a freshly opened file for the new stem.
+func (a *fileAdapter) pruneRotatedFiles(fileDir, stem string) {
+	if a.maxBackups <= 0 && a.maxAgeDays <= 0 && !a.compress {
+		return
+	}
+
+	files, _ := ioutil.ReadDir(fileDir)
+	var backups []os.FileInfo
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(f.Name(), stem) {
+			backups = append(backups, f)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().After(backups[j].ModTime())
+	})
+
+	if a.maxAgeDays > 0 {
+		cutoff := time.Now().Add(-time.Duration(a.maxAgeDays) * 24 * time.Hour)
+		kept := backups[:0]
+		for _, f := range backups {
+			if f.ModTime().Before(cutoff) {
+				os.Remove(fileDir + f.Name())
+				continue
+			}
+			kept = append(kept, f)
+		}
+		backups = kept
+	}
+
+	if a.maxBackups > 0 && len(backups) > a.maxBackups {
+		for _, f := range backups[a.maxBackups:] {
+			os.Remove(fileDir + f.Name())
+		}
+		backups = backups[:a.maxBackups]
+	}
+
+	if a.compress {
+		for _, f := range backups {
+			if strings.HasSuffix(f.Name(), ".gz") {
+				continue
+			}
+			go compressFile(fileDir + f.Name())
+		}
+	}
+}
+
+// compressFile gzips path in place, replacing it with path+".gz". It runs
+// on its own goroutine so rotation never blocks the writer on I/O.
+func compressFile(path string) {
+	in, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	out, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		return
+	}
+	in.Close()
+	os.Remove(path)
+}
@@ -0,0 +1,98 @@
+package logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestPruneRotatedFilesIncludesRetiredStemFile guards against the bug
+// where a date-boundary rotation's now-inactive stem+".log" file was
+// excluded from pruning/compression entirely, so MaxBackups/MaxAgeDays/
+// Compress only ever applied to same-stem size-overflow "_N.log"
+// backups, never to the time-sliced files DateSlice exists for.
+func TestPruneRotatedFilesIncludesRetiredStemFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logger-rotation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileDir := dir + string(os.PathSeparator)
+
+	stem := "2024-01-15"
+	path := filepath.Join(dir, stem+".log")
+	if err := ioutil.WriteFile(path, []byte("yesterday's logs"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := &fileAdapter{compress: true}
+	a.pruneRotatedFiles(fileDir, stem)
+
+	if waitForFile(dir, stem+".log.gz", time.Second) != nil {
+		t.Fatalf("expected the retired stem file to be compressed in the background")
+	}
+	// compressFile creates the .gz before copying into it and removes the
+	// original only once the copy is done, so wait for the removal too.
+	deadline := time.Now().Add(time.Second)
+	for {
+		_, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the original file to be removed after compression, err=%v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func TestPruneRotatedFilesEnforcesMaxBackups(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logger-rotation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fileDir := dir + string(os.PathSeparator)
+
+	stem := "2024-01-15"
+	names := []string{stem + ".log", stem + "_1.log", stem + "_2.log"}
+	for i, name := range names {
+		p := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(p, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		mt := time.Now().Add(time.Duration(i) * time.Second)
+		os.Chtimes(p, mt, mt)
+	}
+
+	a := &fileAdapter{maxBackups: 2}
+	a.pruneRotatedFiles(fileDir, stem)
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected maxBackups=2 to leave 2 files, got %d: %v", len(entries), entries)
+	}
+}
+
+func waitForFile(dir, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		entries, err := ioutil.ReadDir(dir)
+		if err == nil {
+			for _, e := range entries {
+				if e.Name() == name {
+					return nil
+				}
+			}
+		}
+		if time.Now().After(deadline) {
+			return os.ErrNotExist
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}